@@ -0,0 +1,99 @@
+// Package corpus defines a Wycheproof-style schema for hash test vectors
+// and a loader for reading them back in. Generators (such as
+// generate_fnv_corpus.go) produce a Corpus; consumers load it with Load
+// and filter down to the test groups or flags they care about.
+package corpus
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Corpus is the top-level document produced by a generator: a schema
+// version, human-readable notes on the flags used below, and the test
+// groups themselves.
+type Corpus struct {
+	Algorithm        string            `json:"algorithm"`
+	GeneratorVersion string            `json:"generatorVersion"`
+	Schema           string            `json:"schema"`
+	NumberOfTests    int               `json:"numberOfTests"`
+	Notes            map[string]string `json:"notes"`
+	TestGroups       []TestGroup       `json:"testGroups"`
+}
+
+// TestGroup bundles test cases that all exercise the same algorithm
+// variant and output size, so new algorithms can be appended as groups
+// without disturbing existing ones.
+type TestGroup struct {
+	Algorithm string `json:"algorithm"`
+	HashSize  int    `json:"hashSize"`
+	Tests     []Test `json:"tests"`
+}
+
+// Test is a single test case within a TestGroup. Input and Expected are
+// hex-encoded so arbitrary binary inputs and outputs round-trip in JSON.
+type Test struct {
+	TcID      int      `json:"tcId"`
+	Comment   string   `json:"comment"`
+	Input     string   `json:"input"`
+	Expected  string   `json:"expected"`
+	Flags     []string `json:"flags"`
+	HalfState string   `json:"halfState,omitempty"`
+}
+
+// HasFlag reports whether t is tagged with flag.
+func (t Test) HasFlag(flag string) bool {
+	for _, f := range t.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// Load reads and parses a corpus JSON document from path.
+func Load(path string) (*Corpus, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("corpus: reading %s: %w", path, err)
+	}
+	var c Corpus
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("corpus: parsing %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Write encodes c as indented JSON to w, in the format Load expects.
+func Write(w io.Writer, c *Corpus) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c)
+}
+
+// Tests returns every test case across all groups whose Algorithm matches
+// algorithm. An empty algorithm matches every group.
+func (c *Corpus) Tests(algorithm string) []Test {
+	var tests []Test
+	for _, g := range c.TestGroups {
+		if algorithm != "" && g.Algorithm != algorithm {
+			continue
+		}
+		tests = append(tests, g.Tests...)
+	}
+	return tests
+}
+
+// Filter returns the tests matching algorithm and tagged with flag. An
+// empty algorithm or flag matches anything.
+func (c *Corpus) Filter(algorithm, flag string) []Test {
+	var out []Test
+	for _, t := range c.Tests(algorithm) {
+		if flag == "" || t.HasFlag(flag) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,288 @@
+// Package fnv is a pure-Go reference implementation of the FNV-1 and
+// FNV-1a hash functions (32, 64, and 128 bit), built directly from the
+// published offset basis and prime constants rather than delegating to
+// the standard library's hash/fnv. It exists so the corpus generator can
+// cross-validate its output against an independent implementation, and
+// so other implementers have a small, readable reference to port from.
+package fnv
+
+import (
+	"hash"
+	"math/bits"
+)
+
+// Offset bases and primes for FNV-1/FNV-1a, per the FNV specification.
+const (
+	offset32    = 2166136261
+	prime32     = 16777619
+	offset64    = 14695981039346656037
+	prime64     = 1099511628211
+	offset128Hi = 0x6c62272e07bb0142
+	offset128Lo = 0x62b821756295c58d
+)
+
+// prime128 is the 128-bit FNV prime, 2^88 + 2^8 + 0x3b, split into
+// big-endian halves: the 2^88 term lands entirely in the high word
+// (2^88 == 2^24 << 64), and 2^8 + 0x3b lands in the low word.
+var prime128 = uint128{hi: 1 << 24, lo: 1<<8 + 0x3b}
+
+// Hash32 computes the 32-bit FNV-1 hash of data.
+func Hash32(data []byte) uint32 {
+	h := uint32(offset32)
+	for _, b := range data {
+		h *= prime32
+		h ^= uint32(b)
+	}
+	return h
+}
+
+// Hash32a computes the 32-bit FNV-1a hash of data.
+func Hash32a(data []byte) uint32 {
+	h := uint32(offset32)
+	for _, b := range data {
+		h ^= uint32(b)
+		h *= prime32
+	}
+	return h
+}
+
+// Hash64 computes the 64-bit FNV-1 hash of data.
+func Hash64(data []byte) uint64 {
+	h := uint64(offset64)
+	for _, b := range data {
+		h *= prime64
+		h ^= uint64(b)
+	}
+	return h
+}
+
+// Hash64a computes the 64-bit FNV-1a hash of data.
+func Hash64a(data []byte) uint64 {
+	h := uint64(offset64)
+	for _, b := range data {
+		h ^= uint64(b)
+		h *= prime64
+	}
+	return h
+}
+
+// Hash128 computes the 128-bit FNV-1 hash of data, returned as the
+// big-endian (high, low) halves of the result.
+func Hash128(data []byte) (hi, lo uint64) {
+	h := uint128{offset128Hi, offset128Lo}
+	for _, b := range data {
+		h = h.mulPrime()
+		h.lo ^= uint64(b)
+	}
+	return h.hi, h.lo
+}
+
+// Hash128a computes the 128-bit FNV-1a hash of data, returned as the
+// big-endian (high, low) halves of the result.
+func Hash128a(data []byte) (hi, lo uint64) {
+	h := uint128{offset128Hi, offset128Lo}
+	for _, b := range data {
+		h.lo ^= uint64(b)
+		h = h.mulPrime()
+	}
+	return h.hi, h.lo
+}
+
+// uint128 is a 128-bit value held as big-endian halves. It exists purely
+// to carry the running FNV-128 hash; it is not a general-purpose
+// big-number type.
+type uint128 struct {
+	hi, lo uint64
+}
+
+// mul returns h*o truncated to 128 bits (the high half of the true
+// 256-bit product, a.hi*o.hi, is discarded, matching normal fixed-width
+// integer multiplication).
+func (h uint128) mul(o uint128) uint128 {
+	hi, lo := bits.Mul64(h.lo, o.lo)
+	hi += h.lo*o.hi + h.hi*o.lo
+	return uint128{hi: hi, lo: lo}
+}
+
+// mulPrime returns h multiplied by the FNV-128 prime.
+func (h uint128) mulPrime() uint128 {
+	return h.mul(prime128)
+}
+
+// sum32 is a streaming FNV-1 32-bit hash.Hash32.
+type sum32 uint32
+
+// New32 returns a new 32-bit FNV-1 hash.Hash32.
+func New32() hash.Hash32 {
+	s := sum32(offset32)
+	return &s
+}
+
+func (s *sum32) Write(data []byte) (int, error) {
+	h := uint32(*s)
+	for _, b := range data {
+		h *= prime32
+		h ^= uint32(b)
+	}
+	*s = sum32(h)
+	return len(data), nil
+}
+
+func (s *sum32) Sum32() uint32 { return uint32(*s) }
+func (s *sum32) Sum(b []byte) []byte {
+	v := s.Sum32()
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+func (s *sum32) Reset()         { *s = offset32 }
+func (s *sum32) Size() int      { return 4 }
+func (s *sum32) BlockSize() int { return 1 }
+
+// sum32a is a streaming FNV-1a 32-bit hash.Hash32.
+type sum32a uint32
+
+// New32a returns a new 32-bit FNV-1a hash.Hash32.
+func New32a() hash.Hash32 {
+	s := sum32a(offset32)
+	return &s
+}
+
+func (s *sum32a) Write(data []byte) (int, error) {
+	h := uint32(*s)
+	for _, b := range data {
+		h ^= uint32(b)
+		h *= prime32
+	}
+	*s = sum32a(h)
+	return len(data), nil
+}
+
+func (s *sum32a) Sum32() uint32 { return uint32(*s) }
+func (s *sum32a) Sum(b []byte) []byte {
+	v := s.Sum32()
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+func (s *sum32a) Reset()         { *s = offset32 }
+func (s *sum32a) Size() int      { return 4 }
+func (s *sum32a) BlockSize() int { return 1 }
+
+// sum64 is a streaming FNV-1 64-bit hash.Hash64.
+type sum64 uint64
+
+// New64 returns a new 64-bit FNV-1 hash.Hash64.
+func New64() hash.Hash64 {
+	s := sum64(offset64)
+	return &s
+}
+
+func (s *sum64) Write(data []byte) (int, error) {
+	h := uint64(*s)
+	for _, b := range data {
+		h *= prime64
+		h ^= uint64(b)
+	}
+	*s = sum64(h)
+	return len(data), nil
+}
+
+func (s *sum64) Sum64() uint64 { return uint64(*s) }
+func (s *sum64) Sum(b []byte) []byte {
+	v := s.Sum64()
+	return append(b,
+		byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32),
+		byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+func (s *sum64) Reset()         { *s = offset64 }
+func (s *sum64) Size() int      { return 8 }
+func (s *sum64) BlockSize() int { return 1 }
+
+// sum64a is a streaming FNV-1a 64-bit hash.Hash64.
+type sum64a uint64
+
+// New64a returns a new 64-bit FNV-1a hash.Hash64.
+func New64a() hash.Hash64 {
+	s := sum64a(offset64)
+	return &s
+}
+
+func (s *sum64a) Write(data []byte) (int, error) {
+	h := uint64(*s)
+	for _, b := range data {
+		h ^= uint64(b)
+		h *= prime64
+	}
+	*s = sum64a(h)
+	return len(data), nil
+}
+
+func (s *sum64a) Sum64() uint64 { return uint64(*s) }
+func (s *sum64a) Sum(b []byte) []byte {
+	v := s.Sum64()
+	return append(b,
+		byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32),
+		byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+func (s *sum64a) Reset()         { *s = offset64 }
+func (s *sum64a) Size() int      { return 8 }
+func (s *sum64a) BlockSize() int { return 1 }
+
+// sum128 is a streaming FNV-1 128-bit hash.Hash.
+type sum128 uint128
+
+// New128 returns a new 128-bit FNV-1 hash.Hash.
+func New128() hash.Hash {
+	s := sum128{offset128Hi, offset128Lo}
+	return &s
+}
+
+func (s *sum128) Write(data []byte) (int, error) {
+	h := uint128(*s)
+	for _, b := range data {
+		h = h.mulPrime()
+		h.lo ^= uint64(b)
+	}
+	*s = sum128(h)
+	return len(data), nil
+}
+
+func (s *sum128) Sum(b []byte) []byte {
+	h := uint128(*s)
+	return append(b,
+		byte(h.hi>>56), byte(h.hi>>48), byte(h.hi>>40), byte(h.hi>>32),
+		byte(h.hi>>24), byte(h.hi>>16), byte(h.hi>>8), byte(h.hi),
+		byte(h.lo>>56), byte(h.lo>>48), byte(h.lo>>40), byte(h.lo>>32),
+		byte(h.lo>>24), byte(h.lo>>16), byte(h.lo>>8), byte(h.lo))
+}
+func (s *sum128) Reset()         { *s = sum128{offset128Hi, offset128Lo} }
+func (s *sum128) Size() int      { return 16 }
+func (s *sum128) BlockSize() int { return 1 }
+
+// sum128a is a streaming FNV-1a 128-bit hash.Hash.
+type sum128a uint128
+
+// New128a returns a new 128-bit FNV-1a hash.Hash.
+func New128a() hash.Hash {
+	s := sum128a{offset128Hi, offset128Lo}
+	return &s
+}
+
+func (s *sum128a) Write(data []byte) (int, error) {
+	h := uint128(*s)
+	for _, b := range data {
+		h.lo ^= uint64(b)
+		h = h.mulPrime()
+	}
+	*s = sum128a(h)
+	return len(data), nil
+}
+
+func (s *sum128a) Sum(b []byte) []byte {
+	h := uint128(*s)
+	return append(b,
+		byte(h.hi>>56), byte(h.hi>>48), byte(h.hi>>40), byte(h.hi>>32),
+		byte(h.hi>>24), byte(h.hi>>16), byte(h.hi>>8), byte(h.hi),
+		byte(h.lo>>56), byte(h.lo>>48), byte(h.lo>>40), byte(h.lo>>32),
+		byte(h.lo>>24), byte(h.lo>>16), byte(h.lo>>8), byte(h.lo))
+}
+func (s *sum128a) Reset()         { *s = sum128a{offset128Hi, offset128Lo} }
+func (s *sum128a) Size() int      { return 16 }
+func (s *sum128a) BlockSize() int { return 1 }
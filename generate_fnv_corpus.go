@@ -1,37 +1,79 @@
 package main
 
 import (
-	"encoding/json"
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"hash/fnv"
 	"math/rand"
 	"os"
 	"strings"
+
+	"github.com/cmackenzie1/simplehash/corpus"
+	reffnv "github.com/cmackenzie1/simplehash/fnv"
 )
 
-// FNVTestVector represents a test vector for FNV hash function verification
-type FNVTestVector struct {
-	Input      string   `json:"input"`
-	InputBytes []int    `json:"input_bytes"`
-	FNV1_32    uint32   `json:"fnv1_32"`
-	FNV1a_32   uint32   `json:"fnv1a_32"`
-	FNV1_64    uint64   `json:"fnv1_64"`
-	FNV1a_64   uint64   `json:"fnv1a_64"`
+const generatorVersion = "1.0.0"
+
+// testInput is a generated input string tagged with the Wycheproof-style
+// flags describing which category it came from.
+type testInput struct {
+	Value string
+	Flags []string
 }
 
-// generateTestStrings creates a variety of test strings for validation
-func generateTestStrings(count int) []string {
-	testStrings := []string{}
+// HasFlag reports whether in is tagged with flag.
+func (in testInput) HasFlag(flag string) bool {
+	for _, f := range in.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
 
-	// Empty string
-	testStrings = append(testStrings, "")
+// generateTestStrings assembles the full set of tagged test inputs from
+// every category generator below, topping up with randomCount randomly
+// generated ASCII strings. Each category is also exposed as its own
+// function so callers needing only e.g. the boundary cases can call that
+// generator directly instead of paying for the whole set.
+func generateTestStrings(randomCount int) []testInput {
+	inputs := []testInput{}
+	inputs = append(inputs, generateEmptyInput())
+	inputs = append(inputs, generateSingleCharInputs()...)
+	inputs = append(inputs, generateCommonStringInputs()...)
+	inputs = append(inputs, generateAllBytesInput())
+	inputs = append(inputs, generateUTF8Inputs()...)
+	inputs = append(inputs, generateBoundaryInputs()...)
+	inputs = append(inputs, generateLongRepeatInputs()...)
+	inputs = append(inputs, generateCollisionInputs()...)
+	inputs = append(inputs, generateSlidingWindowInput())
+	inputs = append(inputs, generateRandomInputs(randomCount)...)
+	return inputs
+}
 
-	// Single characters
+// generateEmptyInput returns the degenerate empty-string test case.
+func generateEmptyInput() testInput {
+	return testInput{Value: "", Flags: []string{"Empty"}}
+}
+
+// generateSingleCharInputs returns one test case per lowercase letter and
+// digit.
+func generateSingleCharInputs() []testInput {
+	var inputs []testInput
 	for _, c := range "abcdefghijklmnopqrstuvwxyz0123456789" {
-		testStrings = append(testStrings, string(c))
+		inputs = append(inputs, testInput{Value: string(c), Flags: []string{"SingleChar"}})
 	}
+	return inputs
+}
 
-	// Common test strings
+// generateCommonStringInputs returns a handful of short, recognizable
+// strings exercising common alphabets and punctuation.
+func generateCommonStringInputs() []testInput {
 	commonStrings := []string{
 		"hello",
 		"hello world",
@@ -42,84 +84,381 @@ func generateTestStrings(count int) []string {
 		"ABCDEFGHIJKLMNOPQRSTUVWXYZ",
 		"!@#$%^&*()_+-=[]{}|;:,.<>?/",
 	}
-	testStrings = append(testStrings, commonStrings...)
+	inputs := make([]testInput, len(commonStrings))
+	for i, s := range commonStrings {
+		inputs[i] = testInput{Value: s, Flags: []string{"Common"}}
+	}
+	return inputs
+}
+
+// generateAllBytesInput returns a single test case containing every byte
+// value 0-255 exactly once, in ascending order.
+func generateAllBytesInput() testInput {
+	allBytes := make([]byte, 256)
+	for i := range allBytes {
+		allBytes[i] = byte(i)
+	}
+	return testInput{Value: string(allBytes), Flags: []string{"AllBytes"}}
+}
+
+// generateUTF8Inputs returns inputs covering multi-byte UTF-8 sequences:
+// 2/3/4-byte code points, a leading BOM, a combining-mark sequence, and
+// byte sequences that would be a UTF-16 surrogate if (invalidly) decoded
+// as UTF-8, so implementations are exercised on malformed input too.
+func generateUTF8Inputs() []testInput {
+	return []testInput{
+		{Value: "café", Flags: []string{"UTF8"}},                           // 2-byte: é (U+00E9)
+		{Value: "日本語", Flags: []string{"UTF8"}},                            // 3-byte: 日本語
+		{Value: "hello \U0001F600", Flags: []string{"UTF8"}},               // 4-byte: 😀 (U+1F600)
+		{Value: "\uFEFFhello", Flags: []string{"UTF8"}},                    // UTF-8 BOM prefix
+		{Value: "éclair", Flags: []string{"UTF8"}},                        // 'e' + combining acute accent (U+0301)
+		{Value: string([]byte{0xED, 0xA0, 0x80}), Flags: []string{"UTF8"}}, // invalid: encodes surrogate U+D800
+		{Value: string([]byte{0xED, 0xBF, 0xBF}), Flags: []string{"UTF8"}}, // invalid: encodes surrogate U+DFFF
+	}
+}
+
+// boundarySizes are byte lengths that sit on and around buffer sizes a
+// naive streaming implementation is likely to special-case (64, 128,
+// and 4096-byte blocks).
+var boundarySizes = []int{63, 64, 65, 127, 128, 129, 4095, 4096, 4097}
+
+// generateBoundaryInputs returns one fixed-byte-value input per size in
+// boundarySizes.
+func generateBoundaryInputs() []testInput {
+	inputs := make([]testInput, len(boundarySizes))
+	for i, size := range boundarySizes {
+		inputs[i] = testInput{Value: strings.Repeat("A", size), Flags: []string{"Boundary"}}
+	}
+	return inputs
+}
+
+// generateLongRepeatInputs returns long single-byte-repeat inputs. The
+// checked-in corpus caps these at a few tens of KB so the generated JSON
+// stays a reasonable size to commit; generateRepeatInput itself has no
+// such limit, so callers building a larger standalone corpus can ask for
+// the full 1,000,000-byte run directly.
+func generateLongRepeatInputs() []testInput {
+	return []testInput{
+		generateRepeatInput('a', 10_000),
+		generateRepeatInput(0x00, 100_000),
+	}
+}
+
+// generateRepeatInput returns a single input of n copies of b, tagged
+// LongRepeat.
+func generateRepeatInput(b byte, n int) testInput {
+	return testInput{Value: string(bytes.Repeat([]byte{b}, n)), Flags: []string{"LongRepeat"}}
+}
+
+// generateCollisionInputs searches for two short, distinct strings that
+// collide under FNV-1a-32 and returns them alongside diverging variants
+// (same shared prefix, different suffix) so a port can be checked both
+// for reproducing the collision and for correctly diverging afterwards.
+func generateCollisionInputs() []testInput {
+	seen := make(map[uint32]string)
+	var a, b string
+	for i := 0; ; i++ {
+		candidate := fmt.Sprintf("fnv-collision-seed-%d", i)
+		h := fnv.New32a()
+		h.Write([]byte(candidate))
+		sum := h.Sum32()
+
+		if prev, ok := seen[sum]; ok {
+			a, b = prev, candidate
+			break
+		}
+		seen[sum] = candidate
+	}
+
+	return []testInput{
+		{Value: a, Flags: []string{"Collision"}},
+		{Value: b, Flags: []string{"Collision"}},
+		{Value: a + "-diverge-A", Flags: []string{"Collision"}},
+		{Value: b + "-diverge-B", Flags: []string{"Collision"}},
+	}
+}
+
+// generateSlidingWindowInput returns a de Bruijn sequence over all byte
+// values (order 2), so every possible 2-byte window appears exactly once
+// when the input is scanned with a sliding window.
+func generateSlidingWindowInput() testInput {
+	return testInput{Value: string(deBruijnSequence(256)), Flags: []string{"SlidingWindow"}}
+}
+
+// deBruijnSequence returns a de Bruijn sequence B(alphabetSize, 2): a
+// cyclic sequence of alphabetSize^2 bytes (values 0..alphabetSize-1) in
+// which every ordered pair of symbols appears exactly once as a
+// (possibly wrapping) 2-byte window. It's built as an Eulerian circuit,
+// via Hierholzer's algorithm, over the complete digraph on alphabetSize
+// nodes (one edge (u, v) per ordered pair).
+func deBruijnSequence(alphabetSize int) []byte {
+	remaining := make([][]bool, alphabetSize)
+	for u := range remaining {
+		remaining[u] = make([]bool, alphabetSize)
+		for v := range remaining[u] {
+			remaining[u][v] = true
+		}
+	}
+
+	stack := []int{0}
+	circuit := make([]int, 0, alphabetSize*alphabetSize+1)
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+
+		next := -1
+		for v := 0; v < alphabetSize; v++ {
+			if remaining[u][v] {
+				next = v
+				break
+			}
+		}
+
+		if next == -1 {
+			circuit = append(circuit, u)
+			stack = stack[:len(stack)-1]
+			continue
+		}
 
-	// String with all ASCII values
-	var allASCII []byte
-	for i := 0; i < 256; i++ {
-		allASCII = append(allASCII, byte(i))
+		remaining[u][next] = false
+		stack = append(stack, next)
 	}
-	testStrings = append(testStrings, string(allASCII))
 
-	// Random strings of various lengths
+	// circuit was built last-edge-first (Hierholzer's dead-end order);
+	// reverse it to get actual traversal order, then drop the final node
+	// (a repeat of the start) to get the alphabetSize^2-byte cycle.
+	path := make([]int, len(circuit))
+	for i, v := range circuit {
+		path[len(path)-1-i] = v
+	}
+
+	seq := make([]byte, alphabetSize*alphabetSize)
+	for i, v := range path[:len(seq)] {
+		seq[i] = byte(v)
+	}
+	return seq
+}
+
+// generateRandomInputs returns count randomly generated printable-ASCII
+// inputs of varying length, tagged Random (or LongRandom at 50+ bytes).
+func generateRandomInputs(count int) []testInput {
 	chars := "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#$%^&*()_+-=[]{}|;:,.<>?/ "
-	for len(testStrings) < count {
+	inputs := make([]testInput, 0, count)
+	for len(inputs) < count {
 		length := rand.Intn(100) + 1
 		var sb strings.Builder
 		for i := 0; i < length; i++ {
 			sb.WriteByte(chars[rand.Intn(len(chars))])
 		}
-		testStrings = append(testStrings, sb.String())
+		flag := "Random"
+		if length >= 50 {
+			flag = "LongRandom"
+		}
+		inputs = append(inputs, testInput{Value: sb.String(), Flags: []string{flag}})
 	}
+	return inputs
+}
+
+// halfState writes the first half of data into h, marshals h's
+// intermediate state via encoding.BinaryMarshaler, writes the remaining
+// half, and returns the marshaled half-state as base64. h is left holding
+// the hash of the full input, as if data had been written in one call.
+func halfState(h hash.Hash, data []byte) string {
+	half := len(data) / 2
+	h.Write(data[:half])
 
-	return testStrings
+	marshaler, ok := h.(encoding.BinaryMarshaler)
+	if !ok {
+		panic(fmt.Sprintf("%T does not implement encoding.BinaryMarshaler", h))
+	}
+	state, err := marshaler.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+
+	h.Write(data[half:])
+	return base64.StdEncoding.EncodeToString(state)
 }
 
-// calculateFNVHashes computes FNV hash values for each input string
-func calculateFNVHashes(testStrings []string) []FNVTestVector {
-	vectors := []FNVTestVector{}
+// fnvVariant computes one FNV variant's expected output (and, for the
+// 32/64-bit streaming variants, a half-state snapshot) for a test group.
+// reference independently recomputes the same hash via our own
+// simplehash/fnv package, so buildCorpus can catch the two
+// implementations drifting apart.
+type fnvVariant struct {
+	algorithm string
+	hashSize  int
+	newHash   func() hash.Hash
+	toBytes   func(h hash.Hash) []byte
+	reference func(data []byte) []byte
+}
 
-	for _, s := range testStrings {
-		byteSlice := []byte(s)
-		inputBytes := make([]int, len(byteSlice))
-		for i, b := range byteSlice {
-			inputBytes[i] = int(b)
-		}
+var fnvVariants = []fnvVariant{
+	{"FNV-1-32", 32, func() hash.Hash { return fnv.New32() }, sum32Bytes, referenceBytes32(reffnv.Hash32)},
+	{"FNV-1a-32", 32, func() hash.Hash { return fnv.New32a() }, sum32Bytes, referenceBytes32(reffnv.Hash32a)},
+	{"FNV-1-64", 64, func() hash.Hash { return fnv.New64() }, sum64Bytes, referenceBytes64(reffnv.Hash64)},
+	{"FNV-1a-64", 64, func() hash.Hash { return fnv.New64a() }, sum64Bytes, referenceBytes64(reffnv.Hash64a)},
+	{"FNV-1-128", 128, func() hash.Hash { return fnv.New128() }, sum128Bytes, referenceBytes128(reffnv.Hash128)},
+	{"FNV-1a-128", 128, func() hash.Hash { return fnv.New128a() }, sum128Bytes, referenceBytes128(reffnv.Hash128a)},
+}
+
+// referenceBytes32 adapts a simplehash/fnv Hash32-shaped function to the
+// big-endian []byte form used for comparison against stdlib's Sum.
+func referenceBytes32(f func([]byte) uint32) func([]byte) []byte {
+	return func(data []byte) []byte {
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, f(data))
+		return b
+	}
+}
+
+func referenceBytes64(f func([]byte) uint64) func([]byte) []byte {
+	return func(data []byte) []byte {
+		b := make([]byte, 8)
+		binary.BigEndian.PutUint64(b, f(data))
+		return b
+	}
+}
 
-		// Calculate FNV1-32
-		fnv1_32 := fnv.New32()
-		fnv1_32.Write(byteSlice)
-		fnv1_32_hash := fnv1_32.Sum32()
-
-		// Calculate FNV1a-32
-		fnv1a_32 := fnv.New32a()
-		fnv1a_32.Write(byteSlice)
-		fnv1a_32_hash := fnv1a_32.Sum32()
-
-		// Calculate FNV1-64
-		fnv1_64 := fnv.New64()
-		fnv1_64.Write(byteSlice)
-		fnv1_64_hash := fnv1_64.Sum64()
-
-		// Calculate FNV1a-64
-		fnv1a_64 := fnv.New64a()
-		fnv1a_64.Write(byteSlice)
-		fnv1a_64_hash := fnv1a_64.Sum64()
-
-		vector := FNVTestVector{
-			Input:      s,
-			InputBytes: inputBytes,
-			FNV1_32:    fnv1_32_hash,
-			FNV1a_32:   fnv1a_32_hash,
-			FNV1_64:    fnv1_64_hash,
-			FNV1a_64:   fnv1a_64_hash,
+func referenceBytes128(f func([]byte) (hi, lo uint64)) func([]byte) []byte {
+	return func(data []byte) []byte {
+		hi, lo := f(data)
+		b := make([]byte, 16)
+		binary.BigEndian.PutUint64(b[:8], hi)
+		binary.BigEndian.PutUint64(b[8:], lo)
+		return b
+	}
+}
+
+func sum32Bytes(h hash.Hash) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, h.(interface{ Sum32() uint32 }).Sum32())
+	return b
+}
+
+func sum64Bytes(h hash.Hash) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, h.(interface{ Sum64() uint64 }).Sum64())
+	return b
+}
+
+func sum128Bytes(h hash.Hash) []byte {
+	return h.Sum(nil)
+}
+
+// commentFor produces a short human-readable description of a test input
+// based on the category flag it was generated under.
+func commentFor(in testInput) string {
+	switch {
+	case in.HasFlag("Empty"):
+		return "empty input"
+	case in.HasFlag("AllBytes"):
+		return "all 256 byte values in ascending order"
+	case in.HasFlag("UTF8"):
+		return fmt.Sprintf("multi-byte UTF-8 / malformed-UTF-8 input, %d bytes", len(in.Value))
+	case in.HasFlag("Boundary"):
+		return fmt.Sprintf("input at a common buffer-size boundary, %d bytes", len(in.Value))
+	case in.HasFlag("LongRepeat"):
+		return fmt.Sprintf("single byte value repeated %d times", len(in.Value))
+	case in.HasFlag("Collision"):
+		return fmt.Sprintf("FNV-1a-32 collision probe, %d bytes", len(in.Value))
+	case in.HasFlag("SlidingWindow"):
+		return "de Bruijn sequence covering every 2-byte window exactly once"
+	case in.HasFlag("LongRandom"):
+		return fmt.Sprintf("random printable ASCII input, %d bytes", len(in.Value))
+	case in.HasFlag("Random"):
+		return fmt.Sprintf("random printable ASCII input, %d bytes", len(in.Value))
+	case in.HasFlag("SingleChar"):
+		return fmt.Sprintf("single ASCII character %q", in.Value)
+	default:
+		return fmt.Sprintf("common string %q", in.Value)
+	}
+}
+
+// buildCorpus computes every FNV variant over inputs and assembles the
+// result into a corpus.Corpus, assigning a globally unique tcId to each
+// test case in generation order. Every value is cross-validated against
+// the pure-Go reference implementation in simplehash/fnv; a disagreement
+// is treated as a bug in one of the two implementations and aborts
+// generation rather than silently emitting bad vectors.
+func buildCorpus(inputs []testInput) (*corpus.Corpus, error) {
+	tcID := 0
+	nextTcID := func() int {
+		tcID++
+		return tcID
+	}
+
+	var groups []corpus.TestGroup
+	for _, variant := range fnvVariants {
+		tests := make([]corpus.Test, 0, len(inputs))
+		for _, in := range inputs {
+			data := []byte(in.Value)
+			h := variant.newHash()
+			halfStateB64 := halfState(h, data)
+
+			expected := variant.toBytes(h)
+			if reference := variant.reference(data); !bytes.Equal(expected, reference) {
+				return nil, fmt.Errorf("%s disagreement on input %q: hash/fnv=%x simplehash/fnv=%x",
+					variant.algorithm, in.Value, expected, reference)
+			}
+
+			tests = append(tests, corpus.Test{
+				TcID:      nextTcID(),
+				Comment:   commentFor(in),
+				Input:     hex.EncodeToString(data),
+				Expected:  hex.EncodeToString(expected),
+				Flags:     in.Flags,
+				HalfState: halfStateB64,
+			})
 		}
 
-		vectors = append(vectors, vector)
+		groups = append(groups, corpus.TestGroup{
+			Algorithm: variant.algorithm,
+			HashSize:  variant.hashSize,
+			Tests:     tests,
+		})
 	}
 
-	return vectors
+	numberOfTests := 0
+	for _, g := range groups {
+		numberOfTests += len(g.Tests)
+	}
+
+	return &corpus.Corpus{
+		Algorithm:        "FNV",
+		GeneratorVersion: generatorVersion,
+		Schema:           "fnv_test_schema_v1.json",
+		NumberOfTests:    numberOfTests,
+		Notes: map[string]string{
+			"Empty":         "Input is the empty string.",
+			"SingleChar":    "Input is a single ASCII character.",
+			"Common":        "A short, human-recognizable input string.",
+			"AllBytes":      "Input containing every byte value 0-255 exactly once, in order.",
+			"UTF8":          "Input contains multi-byte UTF-8 sequences, a BOM, a combining mark, or bytes that would be an invalid surrogate encoding.",
+			"Boundary":      "Input length sits at or beside a common buffer-size boundary (64/128/4096 bytes).",
+			"LongRepeat":    "Input is a single byte value repeated many times.",
+			"Collision":     "Input is part of a short FNV-1a-32 collision pair, plus diverging variants of each.",
+			"SlidingWindow": "Input is a de Bruijn sequence covering every 2-byte window exactly once.",
+			"Random":        "Randomly generated printable ASCII input under 50 bytes.",
+			"LongRandom":    "Randomly generated printable ASCII input of 50 bytes or more.",
+		},
+		TestGroups: groups,
+	}, nil
 }
 
 func main() {
 	// Set deterministic random seed for reproducibility
 	rand.Seed(42)
 
-	// Generate test strings
-	testStrings := generateTestStrings(200)
+	// Generate test inputs: every fixed category plus 150 random fillers
+	inputs := generateTestStrings(150)
 
-	// Calculate hashes
-	vectors := calculateFNVHashes(testStrings)
+	// Build the structured corpus, cross-validating every hash against
+	// the simplehash/fnv reference implementation as we go.
+	c, err := buildCorpus(inputs)
+	if err != nil {
+		fmt.Println("Error building corpus:", err)
+		os.Exit(1)
+	}
 
 	// Ensure data directory exists
 	if _, err := os.Stat("data"); os.IsNotExist(err) {
@@ -134,12 +473,10 @@ func main() {
 	}
 	defer file.Close()
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(vectors); err != nil {
+	if err := corpus.Write(file, c); err != nil {
 		fmt.Println("Error encoding JSON:", err)
 		return
 	}
 
-	fmt.Printf("Generated FNV test corpus with %d entries.\n", len(vectors))
-}
\ No newline at end of file
+	fmt.Printf("Generated FNV test corpus with %d entries across %d test groups.\n", c.NumberOfTests, len(c.TestGroups))
+}